@@ -1,8 +1,9 @@
-package geddit
+package reddit
 
 import (
 	"context"
 	"errors"
+	"io"
 	"net/url"
 	"strings"
 
@@ -14,6 +15,12 @@ import (
 type LinkService interface {
 	SubmitSelf(ctx context.Context, opts SubmitSelfOptions) (*Submitted, *Response, error)
 	SubmitURL(ctx context.Context, opts SubmitURLOptions) (*Submitted, *Response, error)
+	Crosspost(ctx context.Context, opts CrosspostOptions) (*Submitted, *Response, error)
+
+	SubmitImage(ctx context.Context, opts SubmitImageOptions, filename string, file io.Reader, mimetype string) (*Submitted, *Response, error)
+	SubmitVideo(ctx context.Context, opts SubmitVideoOptions, filename string, file io.Reader, mimetype string) (*Submitted, *Response, error)
+	SubmitGallery(ctx context.Context, opts SubmitGalleryOptions) (*Submitted, *Response, error)
+	UploadMediaImage(ctx context.Context, filename string, file io.Reader, mimetype string) (*MediaAsset, *Response, error)
 
 	EnableReplies(ctx context.Context, id string) (*Response, error)
 	DisableReplies(ctx context.Context, id string) (*Response, error)
@@ -77,6 +84,23 @@ type SubmitURLOptions struct {
 	Spoiler     bool  `url:"spoiler,omitempty"`
 }
 
+// CrosspostOptions are options used for crossposting an existing post
+// into another subreddit
+type CrosspostOptions struct {
+	Subreddit string `url:"sr,omitempty"`
+	Title     string `url:"title,omitempty"`
+
+	// CrosspostFullname is the t3_ fullname of the post being crossposted
+	CrosspostFullname string `url:"crosspost_fullname,omitempty"`
+
+	FlairID   string `url:"flair_id,omitempty"`
+	FlairText string `url:"flair_text,omitempty"`
+
+	SendReplies *bool `url:"sendreplies,omitempty"`
+	NSFW        bool  `url:"nsfw,omitempty"`
+	Spoiler     bool  `url:"spoiler,omitempty"`
+}
+
 // SubmitSelf submits a self text post
 func (s *LinkServiceOp) SubmitSelf(ctx context.Context, opts SubmitSelfOptions) (*Submitted, *Response, error) {
 	type submit struct {
@@ -95,6 +119,15 @@ func (s *LinkServiceOp) SubmitURL(ctx context.Context, opts SubmitURLOptions) (*
 	return s.submit(ctx, &submit{opts, "link"})
 }
 
+// Crosspost submits a crosspost of an existing post into another subreddit
+func (s *LinkServiceOp) Crosspost(ctx context.Context, opts CrosspostOptions) (*Submitted, *Response, error) {
+	type submit struct {
+		CrosspostOptions
+		Kind string `url:"kind,omitempty"`
+	}
+	return s.submit(ctx, &submit{opts, "crosspost"})
+}
+
 func (s *LinkServiceOp) submit(ctx context.Context, v interface{}) (*Submitted, *Response, error) {
 	path := "api/submit"
 
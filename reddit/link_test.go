@@ -0,0 +1,44 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkService_Crosspost(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("sr", "testsubreddit")
+		form.Set("title", "test crosspost")
+		form.Set("crosspost_fullname", "t3_abc123")
+		form.Set("kind", "crosspost")
+		form.Set("api_type", "json")
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		require.Equal(t, form, r.PostForm)
+
+		fmt.Fprint(w, `{"json":{"data":{"id":"xyz789","name":"t3_xyz789","url":"https://www.reddit.com/r/testsubreddit/comments/xyz789/test_crosspost/"}}}`)
+	})
+
+	submitted, _, err := client.Link.Crosspost(ctx, CrosspostOptions{
+		Subreddit:         "testsubreddit",
+		Title:             "test crosspost",
+		CrosspostFullname: "t3_abc123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Submitted{
+		ID:     "xyz789",
+		FullID: "t3_xyz789",
+		URL:    "https://www.reddit.com/r/testsubreddit/comments/xyz789/test_crosspost/",
+	}, submitted)
+}
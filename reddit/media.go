@@ -0,0 +1,342 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// mediaProcessingTimeout bounds how long SubmitImage/SubmitVideo wait on
+// Reddit's websocket for the uploaded asset to finish processing
+const mediaProcessingTimeout = 2 * time.Minute
+
+// MediaAsset is a file uploaded to Reddit's media host. Once its processing
+// completes, it can be attached to a post via SubmitImage, SubmitVideo, or
+// as an item in SubmitGallery
+type MediaAsset struct {
+	ID           string `json:"asset_id,omitempty"`
+	WebsocketURL string `json:"websocket_url,omitempty"`
+}
+
+type mediaUploadLease struct {
+	Args struct {
+		Action string `json:"action"`
+		Fields []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"fields"`
+	} `json:"args"`
+	Asset struct {
+		AssetID      string `json:"asset_id"`
+		WebsocketURL string `json:"websocket_url"`
+	} `json:"asset"`
+}
+
+// SubmitImageOptions are options used for image post submissions
+type SubmitImageOptions struct {
+	Subreddit string `url:"sr,omitempty"`
+	Title     string `url:"title,omitempty"`
+
+	FlairID   string `url:"flair_id,omitempty"`
+	FlairText string `url:"flair_text,omitempty"`
+
+	SendReplies *bool `url:"sendreplies,omitempty"`
+	NSFW        bool  `url:"nsfw,omitempty"`
+	Spoiler     bool  `url:"spoiler,omitempty"`
+}
+
+// SubmitVideoOptions are options used for video and videogif post submissions
+type SubmitVideoOptions struct {
+	Subreddit string `url:"sr,omitempty"`
+	Title     string `url:"title,omitempty"`
+
+	// VideoPosterURL is the CDN URL of an already-uploaded thumbnail image
+	VideoPosterURL string `url:"video_poster_url,omitempty"`
+	// VideoGIF submits the upload as a silent, looping videogif instead of a video
+	VideoGIF bool `url:"-"`
+
+	FlairID   string `url:"flair_id,omitempty"`
+	FlairText string `url:"flair_text,omitempty"`
+
+	SendReplies *bool `url:"sendreplies,omitempty"`
+	NSFW        bool  `url:"nsfw,omitempty"`
+	Spoiler     bool  `url:"spoiler,omitempty"`
+}
+
+// GalleryItem is a single already-uploaded image in a gallery post submission
+type GalleryItem struct {
+	AssetID     string
+	Caption     string
+	OutboundURL string
+}
+
+// SubmitGalleryOptions are options used for gallery post submissions
+type SubmitGalleryOptions struct {
+	Subreddit string
+	Title     string
+
+	FlairID   string
+	FlairText string
+
+	SendReplies *bool
+	NSFW        bool
+	Spoiler     bool
+
+	// Items are the uploaded gallery images, in display order
+	Items []GalleryItem
+}
+
+// SubmitImage uploads an image from file and submits it as an image post
+func (s *LinkServiceOp) SubmitImage(ctx context.Context, opts SubmitImageOptions, filename string, file io.Reader, mimetype string) (*Submitted, *Response, error) {
+	asset, resp, err := s.uploadMedia(ctx, filename, file, mimetype)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, mediaProcessingTimeout)
+	defer cancel()
+
+	cdnURL, err := waitForMediaURL(waitCtx, asset.WebsocketURL)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	type submit struct {
+		SubmitImageOptions
+		Kind string `url:"kind,omitempty"`
+		URL  string `url:"url,omitempty"`
+	}
+	return s.submit(ctx, &submit{opts, "image", cdnURL})
+}
+
+// SubmitVideo uploads a video from file and submits it as a video (or, if
+// opts.VideoGIF is set, videogif) post
+func (s *LinkServiceOp) SubmitVideo(ctx context.Context, opts SubmitVideoOptions, filename string, file io.Reader, mimetype string) (*Submitted, *Response, error) {
+	asset, resp, err := s.uploadMedia(ctx, filename, file, mimetype)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, mediaProcessingTimeout)
+	defer cancel()
+
+	cdnURL, err := waitForMediaURL(waitCtx, asset.WebsocketURL)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	kind := "video"
+	if opts.VideoGIF {
+		kind = "videogif"
+	}
+
+	type submit struct {
+		SubmitVideoOptions
+		Kind string `url:"kind,omitempty"`
+		URL  string `url:"url,omitempty"`
+	}
+	return s.submit(ctx, &submit{opts, kind, cdnURL})
+}
+
+type galleryItemPayload struct {
+	MediaID     string `json:"media_id"`
+	Caption     string `json:"caption,omitempty"`
+	OutboundURL string `json:"outbound_url,omitempty"`
+}
+
+type submitGalleryPostRequest struct {
+	Subreddit string `json:"sr"`
+	Title     string `json:"title"`
+
+	FlairID   string `json:"flair_id,omitempty"`
+	FlairText string `json:"flair_text,omitempty"`
+
+	SendReplies *bool `json:"sendreplies,omitempty"`
+	NSFW        bool  `json:"nsfw,omitempty"`
+	Spoiler     bool  `json:"spoiler,omitempty"`
+
+	Items []galleryItemPayload `json:"items"`
+}
+
+// SubmitGallery submits a gallery post made up of already-uploaded media assets.
+// Use UploadMediaImage to upload each image beforehand
+func (s *LinkServiceOp) SubmitGallery(ctx context.Context, opts SubmitGalleryOptions) (*Submitted, *Response, error) {
+	if len(opts.Items) == 0 {
+		return nil, nil, errors.New("must provide at least 1 gallery item")
+	}
+
+	items := make([]galleryItemPayload, len(opts.Items))
+	for i, item := range opts.Items {
+		items[i] = galleryItemPayload{MediaID: item.AssetID, Caption: item.Caption, OutboundURL: item.OutboundURL}
+	}
+
+	body := &submitGalleryPostRequest{
+		Subreddit:   opts.Subreddit,
+		Title:       opts.Title,
+		FlairID:     opts.FlairID,
+		FlairText:   opts.FlairText,
+		SendReplies: opts.SendReplies,
+		NSFW:        opts.NSFW,
+		Spoiler:     opts.Spoiler,
+		Items:       items,
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "api/submit_gallery_post.json", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(submittedLinkRoot)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.JSON.Data, resp, nil
+}
+
+// UploadMediaImage uploads an image file to Reddit's media host and returns
+// the resulting asset, which can be reused as a SubmitGallery item
+func (s *LinkServiceOp) UploadMediaImage(ctx context.Context, filename string, file io.Reader, mimetype string) (*MediaAsset, *Response, error) {
+	return s.uploadMedia(ctx, filename, file, mimetype)
+}
+
+// uploadMedia implements Reddit's 2-step media upload flow: it leases an
+// upload slot via api/media/asset.json, then streams file to the returned
+// S3 action URL
+func (s *LinkServiceOp) uploadMedia(ctx context.Context, filename string, file io.Reader, mimetype string) (*MediaAsset, *Response, error) {
+	lease, resp, err := s.requestMediaLease(ctx, filename, mimetype)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if err := uploadMediaAsset(ctx, lease, filename, file); err != nil {
+		return nil, resp, err
+	}
+
+	return &MediaAsset{ID: lease.Asset.AssetID, WebsocketURL: lease.Asset.WebsocketURL}, resp, nil
+}
+
+func (s *LinkServiceOp) requestMediaLease(ctx context.Context, filename, mimetype string) (*mediaUploadLease, *Response, error) {
+	form := url.Values{}
+	form.Set("filepath", filename)
+	form.Set("mimetype", mimetype)
+
+	req, err := s.client.NewPostForm("api/media/asset.json", form)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lease := new(mediaUploadLease)
+	resp, err := s.client.Do(ctx, req, lease)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lease, resp, nil
+}
+
+// uploadMediaAsset streams file into a multipart/form-data request to the
+// S3 action URL from a media lease, so large videos aren't buffered in memory
+func uploadMediaAsset(ctx context.Context, lease *mediaUploadLease, filename string, file io.Reader) error {
+	action := lease.Args.Action
+	if strings.HasPrefix(action, "//") {
+		action = "https:" + action
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		for _, field := range lease.Args.Fields {
+			if err := writer.WriteField(field.Name, field.Value); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+
+		part, err := writer.CreateFormFile("file", filename)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		pw.CloseWithError(writer.Close())
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action, pr)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("reddit: media upload to asset host failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// waitForMediaURL connects to the websocket Reddit hands back from a media
+// lease and blocks until it reports the asset's processing outcome, ctx is
+// canceled, or ctx's deadline passes
+func waitForMediaURL(ctx context.Context, websocketURL string) (string, error) {
+	ws, err := websocket.Dial(websocketURL, "", "https://www.reddit.com")
+	if err != nil {
+		return "", err
+	}
+	defer ws.Close()
+
+	// websocket.Conn has no context awareness of its own; closing the
+	// connection is what unblocks the blocking Receive call below
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-done:
+		}
+	}()
+
+	var message struct {
+		Type    string `json:"type"`
+		Payload struct {
+			RedirectURL string `json:"redirect"`
+		} `json:"payload"`
+	}
+
+	for {
+		if err := websocket.JSON.Receive(ws, &message); err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", err
+		}
+
+		switch message.Type {
+		case "success":
+			return message.Payload.RedirectURL, nil
+		case "failed":
+			return "", errors.New("reddit: media processing failed")
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// mediaTestServers wires up the S3 upload endpoint and the websocket
+// completion endpoint a media lease points at, so SubmitImage/SubmitVideo
+// can be exercised end to end against the mux like every other request
+func mediaTestServers(t *testing.T, wsType string) (s3 *httptest.Server, ws *httptest.Server) {
+	s3 = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseMultipartForm(32<<20))
+		require.Equal(t, "test-bucket-key", r.MultipartForm.Value["key"][0])
+
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	ws = httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		err := websocket.JSON.Send(conn, struct {
+			Type    string `json:"type"`
+			Payload struct {
+				RedirectURL string `json:"redirect"`
+			} `json:"payload"`
+		}{
+			Type: wsType,
+			Payload: struct {
+				RedirectURL string `json:"redirect"`
+			}{RedirectURL: "https://i.redd.it/test.png"},
+		})
+		require.NoError(t, err)
+	}))
+
+	return s3, ws
+}
+
+func TestLinkService_SubmitImage(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	s3, ws := mediaTestServers(t, "success")
+	defer s3.Close()
+	defer ws.Close()
+
+	mux.HandleFunc("/api/media/asset.json", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("filepath", "image.png")
+		form.Set("mimetype", "image/png")
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.PostForm)
+
+		fmt.Fprintf(w, `{
+			"args": {
+				"action": %q,
+				"fields": [{"name": "key", "value": "test-bucket-key"}]
+			},
+			"asset": {
+				"asset_id": "abc123",
+				"websocket_url": %q
+			}
+		}`, s3.URL, "ws"+strings.TrimPrefix(ws.URL, "http"))
+	})
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("sr", "testsubreddit")
+		form.Set("title", "test image")
+		form.Set("kind", "image")
+		form.Set("url", "https://i.redd.it/test.png")
+		form.Set("api_type", "json")
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.PostForm)
+
+		fmt.Fprint(w, `{"json":{"data":{"id":"xyz789","name":"t3_xyz789","url":"https://www.reddit.com/r/testsubreddit/comments/xyz789/test_image/"}}}`)
+	})
+
+	submitted, _, err := client.Link.SubmitImage(ctx, SubmitImageOptions{
+		Subreddit: "testsubreddit",
+		Title:     "test image",
+	}, "image.png", strings.NewReader("fake image bytes"), "image/png")
+	require.NoError(t, err)
+	require.Equal(t, &Submitted{
+		ID:     "xyz789",
+		FullID: "t3_xyz789",
+		URL:    "https://www.reddit.com/r/testsubreddit/comments/xyz789/test_image/",
+	}, submitted)
+}
+
+func TestLinkService_SubmitVideo(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	s3, ws := mediaTestServers(t, "success")
+	defer s3.Close()
+	defer ws.Close()
+
+	mux.HandleFunc("/api/media/asset.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"args": {
+				"action": %q,
+				"fields": [{"name": "key", "value": "test-bucket-key"}]
+			},
+			"asset": {
+				"asset_id": "abc123",
+				"websocket_url": %q
+			}
+		}`, s3.URL, "ws"+strings.TrimPrefix(ws.URL, "http"))
+	})
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "videogif", r.FormValue("kind"))
+		fmt.Fprint(w, `{"json":{"data":{"id":"xyz789","name":"t3_xyz789","url":"https://www.reddit.com/r/testsubreddit/comments/xyz789/test_video/"}}}`)
+	})
+
+	submitted, _, err := client.Link.SubmitVideo(ctx, SubmitVideoOptions{
+		Subreddit: "testsubreddit",
+		Title:     "test video",
+		VideoGIF:  true,
+	}, "video.gif", strings.NewReader("fake video bytes"), "video/mp4")
+	require.NoError(t, err)
+	require.Equal(t, "t3_xyz789", submitted.FullID)
+}
+
+func TestLinkService_SubmitImage_ProcessingFailed(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	s3, ws := mediaTestServers(t, "failed")
+	defer s3.Close()
+	defer ws.Close()
+
+	mux.HandleFunc("/api/media/asset.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"args": {
+				"action": %q,
+				"fields": [{"name": "key", "value": "test-bucket-key"}]
+			},
+			"asset": {
+				"asset_id": "abc123",
+				"websocket_url": %q
+			}
+		}`, s3.URL, "ws"+strings.TrimPrefix(ws.URL, "http"))
+	})
+
+	_, _, err := client.Link.SubmitImage(ctx, SubmitImageOptions{Subreddit: "testsubreddit", Title: "test image"}, "image.png", strings.NewReader("fake image bytes"), "image/png")
+	require.EqualError(t, err, "reddit: media processing failed")
+}
+
+func TestLinkService_SubmitGallery(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/submit_gallery_post.json", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		fmt.Fprint(w, `{"json":{"data":{"id":"xyz789","name":"t3_xyz789","url":"https://www.reddit.com/r/testsubreddit/comments/xyz789/test_gallery/"}}}`)
+	})
+
+	submitted, _, err := client.Link.SubmitGallery(ctx, SubmitGalleryOptions{
+		Subreddit: "testsubreddit",
+		Title:     "test gallery",
+		Items: []GalleryItem{
+			{AssetID: "abc123", Caption: "first"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Submitted{
+		ID:     "xyz789",
+		FullID: "t3_xyz789",
+		URL:    "https://www.reddit.com/r/testsubreddit/comments/xyz789/test_gallery/",
+	}, submitted)
+}
+
+func TestLinkService_SubmitGallery_NoItems(t *testing.T) {
+	client, _, teardown := setup()
+	defer teardown()
+
+	_, _, err := client.Link.SubmitGallery(ctx, SubmitGalleryOptions{Subreddit: "testsubreddit", Title: "test gallery"})
+	require.EqualError(t, err, "must provide at least 1 gallery item")
+}
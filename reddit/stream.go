@@ -0,0 +1,363 @@
+package reddit
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultStreamPollInterval is used when StreamOptions doesn't specify
+	// a PollInterval
+	defaultStreamPollInterval = 5 * time.Second
+	// minStreamPollInterval is the fastest a Stream is allowed to poll
+	minStreamPollInterval = 2 * time.Second
+	// seenFullnamesCap bounds how many fullnames a stream remembers, matching
+	// the 100-item page size plus headroom for Reddit's listing cap
+	seenFullnamesCap = 301
+	// streamFetchLimit is the page size requested on every poll
+	streamFetchLimit = 100
+)
+
+// StreamOptions configures how a Stream polls
+type StreamOptions struct {
+	// PollInterval is how often the listing endpoint is polled. It defaults
+	// to 5 seconds and is floored at 2 seconds
+	PollInterval time.Duration
+	// SkipExisting discards the first page of results so only items created
+	// after the stream started are delivered
+	SkipExisting bool
+}
+
+func (o *StreamOptions) pollInterval() time.Duration {
+	if o == nil || o.PollInterval == 0 {
+		return defaultStreamPollInterval
+	}
+	if o.PollInterval < minStreamPollInterval {
+		return minStreamPollInterval
+	}
+	return o.PollInterval
+}
+
+func (o *StreamOptions) skipExisting() bool {
+	return o != nil && o.SkipExisting
+}
+
+// StreamService continuously polls listing endpoints and delivers new posts,
+// comments, and mod actions over a channel, modeled on PRAW's stream helpers
+type StreamService interface {
+	Posts(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *Post, <-chan error)
+	Comments(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *Comment, <-chan error)
+	ModActions(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *ModAction, <-chan error)
+}
+
+// StreamServiceOp implements the StreamService interface
+type StreamServiceOp struct {
+	client *Client
+}
+
+var _ StreamService = &StreamServiceOp{}
+
+// streamItem is a listing item a Stream can dedupe and order by fullname
+type streamItem interface {
+	fullname() string
+}
+
+func (p *Post) fullname() string      { return p.FullID }
+func (c *Comment) fullname() string   { return c.FullID }
+func (m *ModAction) fullname() string { return m.FullID }
+
+// seenFullnames is a bounded history of fullnames a stream has already
+// delivered, used to tell genuinely new listing items apart from a replay
+type seenFullnames struct {
+	cap   int
+	order *list.List
+	index map[string]*list.Element
+}
+
+func newSeenFullnames(cap int) *seenFullnames {
+	return &seenFullnames{
+		cap:   cap,
+		order: list.New(),
+		index: make(map[string]*list.Element, cap),
+	}
+}
+
+func (s *seenFullnames) has(fullname string) bool {
+	_, ok := s.index[fullname]
+	return ok
+}
+
+func (s *seenFullnames) add(fullname string) {
+	if s.has(fullname) {
+		return
+	}
+
+	s.index[fullname] = s.order.PushBack(fullname)
+	if s.order.Len() <= s.cap {
+		return
+	}
+
+	oldest := s.order.Front()
+	s.order.Remove(oldest)
+	delete(s.index, oldest.Value.(string))
+}
+
+type rootStreamPosts struct {
+	Data struct {
+		Children []*Post `json:"children"`
+	} `json:"data"`
+}
+
+type rootStreamComments struct {
+	Data struct {
+		Children []*Comment `json:"children"`
+	} `json:"data"`
+}
+
+type rootStreamModActions struct {
+	Data struct {
+		Children []*ModAction `json:"children"`
+	} `json:"data"`
+}
+
+// Posts streams new posts submitted to subreddit
+func (s *StreamServiceOp) Posts(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *Post, <-chan error) {
+	posts := make(chan *Post)
+	errs := make(chan error)
+
+	go func() {
+		defer close(posts)
+		defer close(errs)
+
+		pollStream(ctx, opts, errs,
+			func(ctx context.Context, before string) ([]streamItem, error) {
+				items, err := s.fetchPosts(ctx, subreddit, before)
+				return postsToStreamItems(items), err
+			},
+			func(ctx context.Context, item streamItem) bool {
+				select {
+				case posts <- item.(*Post):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			},
+		)
+	}()
+
+	return posts, errs
+}
+
+// Comments streams new comments posted to subreddit
+func (s *StreamServiceOp) Comments(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *Comment, <-chan error) {
+	comments := make(chan *Comment)
+	errs := make(chan error)
+
+	go func() {
+		defer close(comments)
+		defer close(errs)
+
+		pollStream(ctx, opts, errs,
+			func(ctx context.Context, before string) ([]streamItem, error) {
+				items, err := s.fetchComments(ctx, subreddit, before)
+				return commentsToStreamItems(items), err
+			},
+			func(ctx context.Context, item streamItem) bool {
+				select {
+				case comments <- item.(*Comment):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			},
+		)
+	}()
+
+	return comments, errs
+}
+
+// ModActions streams new moderation log entries for subreddit
+func (s *StreamServiceOp) ModActions(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *ModAction, <-chan error) {
+	actions := make(chan *ModAction)
+	errs := make(chan error)
+
+	go func() {
+		defer close(actions)
+		defer close(errs)
+
+		pollStream(ctx, opts, errs,
+			func(ctx context.Context, before string) ([]streamItem, error) {
+				items, err := s.fetchModActions(ctx, subreddit, before)
+				return modActionsToStreamItems(items), err
+			},
+			func(ctx context.Context, item streamItem) bool {
+				select {
+				case actions <- item.(*ModAction):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			},
+		)
+	}()
+
+	return actions, errs
+}
+
+func postsToStreamItems(items []*Post) []streamItem {
+	out := make([]streamItem, len(items))
+	for i, item := range items {
+		out[i] = item
+	}
+	return out
+}
+
+func commentsToStreamItems(items []*Comment) []streamItem {
+	out := make([]streamItem, len(items))
+	for i, item := range items {
+		out[i] = item
+	}
+	return out
+}
+
+func modActionsToStreamItems(items []*ModAction) []streamItem {
+	out := make([]streamItem, len(items))
+	for i, item := range items {
+		out[i] = item
+	}
+	return out
+}
+
+// pollStream is the poll/dedupe/deliver loop shared by Posts, Comments, and
+// ModActions: on each tick it fetches whatever is newer than the last seen
+// fullname, falling back to a full fetch (and a diff against the seen
+// history) if that comes back empty or before turns out to be stale
+func pollStream(
+	ctx context.Context,
+	opts *StreamOptions,
+	errs chan<- error,
+	fetch func(ctx context.Context, before string) ([]streamItem, error),
+	deliver func(ctx context.Context, item streamItem) bool,
+) {
+	seen := newSeenFullnames(seenFullnamesCap)
+	before := ""
+	first := true
+
+	ticker := time.NewTicker(opts.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		items, err := fetch(ctx, before)
+		if err == nil && len(items) == 0 && before != "" {
+			items, err = fetch(ctx, "")
+		}
+
+		if err != nil {
+			if !deliverErr(ctx, errs, err) {
+				return
+			}
+		} else {
+			for i := len(items) - 1; i >= 0; i-- {
+				item := items[i]
+				if seen.has(item.fullname()) {
+					continue
+				}
+				seen.add(item.fullname())
+
+				if first && opts.skipExisting() {
+					continue
+				}
+				if !deliver(ctx, item) {
+					return
+				}
+			}
+
+			if len(items) > 0 {
+				before = items[0].fullname()
+			}
+			first = false
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *StreamServiceOp) fetchPosts(ctx context.Context, subreddit, before string) ([]*Post, error) {
+	path, err := streamPath(fmt.Sprintf("r/%s/new", subreddit), before)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(rootStreamPosts)
+	if _, err := s.client.Do(ctx, req, root); err != nil {
+		return nil, err
+	}
+
+	return root.Data.Children, nil
+}
+
+func (s *StreamServiceOp) fetchComments(ctx context.Context, subreddit, before string) ([]*Comment, error) {
+	path, err := streamPath(fmt.Sprintf("r/%s/comments", subreddit), before)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(rootStreamComments)
+	if _, err := s.client.Do(ctx, req, root); err != nil {
+		return nil, err
+	}
+
+	return root.Data.Children, nil
+}
+
+func (s *StreamServiceOp) fetchModActions(ctx context.Context, subreddit, before string) ([]*ModAction, error) {
+	path, err := streamPath(fmt.Sprintf("r/%s/about/log", subreddit), before)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(rootStreamModActions)
+	if _, err := s.client.Do(ctx, req, root); err != nil {
+		return nil, err
+	}
+
+	return root.Data.Children, nil
+}
+
+func streamPath(path, before string) (string, error) {
+	return addOptions(path, &struct {
+		Before string `url:"before,omitempty"`
+		Limit  int    `url:"limit"`
+	}{before, streamFetchLimit})
+}
+
+func deliverErr(ctx context.Context, ch chan<- error, err error) bool {
+	select {
+	case ch <- err:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,140 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStreamItem string
+
+func (i fakeStreamItem) fullname() string { return string(i) }
+
+func fakeStreamItems(fullnames ...string) []streamItem {
+	items := make([]streamItem, len(fullnames))
+	for i, fullname := range fullnames {
+		items[i] = fakeStreamItem(fullname)
+	}
+	return items
+}
+
+func TestPollStream_DedupesAcrossPolls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	fetch := func(ctx context.Context, before string) ([]streamItem, error) {
+		calls++
+		switch calls {
+		case 1:
+			// newest first, as Reddit listings are ordered
+			return fakeStreamItems("t3_c", "t3_b", "t3_a"), nil
+		case 2:
+			// t3_b and t3_c were already seen; only t3_e and t3_d are new
+			cancel()
+			return fakeStreamItems("t3_e", "t3_d", "t3_c", "t3_b"), nil
+		default:
+			t.Fatalf("unexpected fetch call %d", calls)
+			return nil, nil
+		}
+	}
+
+	var delivered []string
+	deliver := func(ctx context.Context, item streamItem) bool {
+		delivered = append(delivered, item.fullname())
+		return true
+	}
+
+	pollStream(ctx, &StreamOptions{PollInterval: minStreamPollInterval}, make(chan error, 1), fetch, deliver)
+
+	require.Equal(t, []string{"t3_a", "t3_b", "t3_c", "t3_d", "t3_e"}, delivered)
+}
+
+func TestPollStream_SkipExisting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetch := func(ctx context.Context, before string) ([]streamItem, error) {
+		cancel()
+		return fakeStreamItems("t3_b", "t3_a"), nil
+	}
+
+	var delivered []string
+	deliver := func(ctx context.Context, item streamItem) bool {
+		delivered = append(delivered, item.fullname())
+		return true
+	}
+
+	pollStream(ctx, &StreamOptions{SkipExisting: true}, make(chan error, 1), fetch, deliver)
+
+	require.Empty(t, delivered, "the first page should be marked seen but not delivered")
+}
+
+func TestPollStream_FallsBackToFullFetchOnStaleBefore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var befores []string
+	var calls int
+	fetch := func(ctx context.Context, before string) ([]streamItem, error) {
+		calls++
+		befores = append(befores, before)
+
+		switch calls {
+		case 1:
+			return fakeStreamItems("t3_a"), nil
+		case 2:
+			// before is now stale (e.g. the item fell out of the listing);
+			// pollStream must fall back to an unfiltered fetch
+			return nil, nil
+		case 3:
+			cancel()
+			return fakeStreamItems("t3_b"), nil
+		default:
+			t.Fatalf("unexpected fetch call %d", calls)
+			return nil, nil
+		}
+	}
+
+	var delivered []string
+	deliver := func(ctx context.Context, item streamItem) bool {
+		delivered = append(delivered, item.fullname())
+		return true
+	}
+
+	pollStream(ctx, &StreamOptions{PollInterval: minStreamPollInterval}, make(chan error, 1), fetch, deliver)
+
+	require.Equal(t, []string{"", "t3_a", ""}, befores)
+	require.Equal(t, []string{"t3_a", "t3_b"}, delivered)
+}
+
+func TestPollStream_DeliversErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	boom := errors.New("boom")
+
+	var calls int
+	fetch := func(ctx context.Context, before string) ([]streamItem, error) {
+		calls++
+		return nil, boom
+	}
+
+	errs := make(chan error, 1)
+	deliver := func(ctx context.Context, item streamItem) bool {
+		t.Fatal("deliver should not be called when fetch errors")
+		return true
+	}
+
+	done := make(chan struct{})
+	go func() {
+		pollStream(ctx, nil, errs, fetch, deliver)
+		close(done)
+	}()
+
+	require.Equal(t, boom, <-errs)
+	cancel()
+	<-done
+}
@@ -0,0 +1,343 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// maxRetries is how many times Transport retries a 429 or 503 response
+// before giving up and returning it to the caller
+const maxRetries = 3
+
+// maxBackoff caps the exponential backoff used between retries
+const maxBackoff = 30 * time.Second
+
+// GrantType identifies which OAuth2 flow Transport uses to obtain tokens
+type GrantType string
+
+const (
+	// GrantTypePassword authenticates as a specific Reddit user, for
+	// script apps (the OAuth2 "password" grant)
+	GrantTypePassword GrantType = "password"
+	// GrantTypeClientCredentials authenticates app-only, for installed
+	// and web apps (the OAuth2 "client_credentials" grant)
+	GrantTypeClientCredentials GrantType = "client_credentials"
+)
+
+// ErrRateLimited is returned by Transport when a request would exceed
+// Reddit's rate limit and it is not configured to block until reset
+var ErrRateLimited = errors.New("reddit: rate limited")
+
+// RateLimit is a snapshot of the rate limit state Reddit reported on the
+// most recently completed request
+type RateLimit struct {
+	// Remaining is the number of requests left in the current window
+	Remaining float64
+	// Used is the number of requests already made in the current window
+	Used int
+	// Reset is when the current window ends
+	Reset time.Time
+}
+
+// TransportOptions configures a Transport
+type TransportOptions struct {
+	GrantType GrantType
+
+	ClientID     string
+	ClientSecret string
+
+	// Username and Password are required for GrantTypePassword
+	Username string
+	Password string
+
+	// TokenURL defaults to Reddit's access token endpoint
+	TokenURL string
+
+	// Base is the underlying RoundTripper requests are sent through;
+	// defaults to http.DefaultTransport
+	Base http.RoundTripper
+
+	// BlockOnRateLimit makes RoundTrip block until the rate limit window
+	// resets instead of returning ErrRateLimited
+	BlockOnRateLimit bool
+}
+
+// tokenFetcher performs an uncached, one-shot token exchange for whichever
+// grant type Transport was configured with
+type tokenFetcher func(ctx context.Context) (*oauth2.Token, error)
+
+// Transport is an http.RoundTripper that authenticates requests with an
+// OAuth2 bearer token, transparently refreshing it on expiry or a 401, and
+// throttles requests according to the rate limit headers Reddit returns
+type Transport struct {
+	base  http.RoundTripper
+	block bool
+	fetch tokenFetcher
+
+	mu          sync.Mutex
+	cachedToken *oauth2.Token
+	rate        RateLimit
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// NewTransport builds a Transport from opts, performing the initial token
+// exchange for the configured grant type
+func NewTransport(opts *TransportOptions) (*Transport, error) {
+	if opts == nil {
+		return nil, errors.New("opts: cannot be nil")
+	}
+
+	base := opts.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	tokenURL := opts.TokenURL
+	if tokenURL == "" {
+		tokenURL = "https://www.reddit.com/api/v1/access_token"
+	}
+
+	var fetch tokenFetcher
+	switch opts.GrantType {
+	case GrantTypePassword:
+		cfg := &oauth2.Config{
+			ClientID:     opts.ClientID,
+			ClientSecret: opts.ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: tokenURL},
+		}
+		fetch = func(ctx context.Context) (*oauth2.Token, error) {
+			return cfg.PasswordCredentialsToken(httpClientCtx(ctx, base), opts.Username, opts.Password)
+		}
+	case GrantTypeClientCredentials:
+		cfg := &clientcredentials.Config{
+			ClientID:     opts.ClientID,
+			ClientSecret: opts.ClientSecret,
+			TokenURL:     tokenURL,
+		}
+		// cfg.Token, unlike cfg.TokenSource, always performs a fresh
+		// exchange rather than reusing a cached, possibly-stale token
+		fetch = func(ctx context.Context) (*oauth2.Token, error) {
+			return cfg.Token(httpClientCtx(ctx, base))
+		}
+	default:
+		return nil, fmt.Errorf("reddit: unsupported grant type %q", opts.GrantType)
+	}
+
+	token, err := fetch(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("reddit: fetching oauth2 token: %w", err)
+	}
+
+	return &Transport{
+		base:        base,
+		block:       opts.BlockOnRateLimit,
+		fetch:       fetch,
+		cachedToken: token,
+	}, nil
+}
+
+func httpClientCtx(ctx context.Context, base http.RoundTripper) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: base})
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.await(req.Context()); err != nil {
+		return nil, err
+	}
+
+	token, err := t.token(req.Context(), false)
+	if err != nil {
+		return nil, fmt.Errorf("reddit: refreshing oauth2 token: %w", err)
+	}
+
+	res, err := t.do(req, token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		res.Body.Close()
+
+		if token, err = t.token(req.Context(), true); err != nil {
+			return nil, fmt.Errorf("reddit: refreshing oauth2 token: %w", err)
+		}
+
+		if res, err = t.do(req, token.AccessToken); err != nil {
+			return nil, err
+		}
+	}
+
+	t.recordRateLimit(res)
+
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+		return t.retry(req, res)
+	}
+
+	return res, nil
+}
+
+// do sends a copy of req with a fresh, unconsumed body so the same request
+// can be safely replayed across the 401 retry and the 429/503 backoff loop
+func (t *Transport) do(req *http.Request, accessToken string) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("reddit: rewinding request body: %w", err)
+		}
+		clone.Body = body
+	}
+
+	clone.Header.Set("Authorization", "Bearer "+accessToken)
+	return t.base.RoundTrip(clone)
+}
+
+// token returns the cached access token if it's still valid, or fetches a
+// genuinely new one via t.fetch if forceRefresh is set (used after a 401,
+// since Reddit can reject a token before its advertised expiry) or if the
+// cached token has expired
+func (t *Transport) token(ctx context.Context, forceRefresh bool) (*oauth2.Token, error) {
+	t.mu.Lock()
+	cur := t.cachedToken
+	t.mu.Unlock()
+
+	if !forceRefresh && cur.Valid() {
+		return cur, nil
+	}
+
+	fresh, err := t.fetch(httpClientCtx(ctx, t.base))
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.cachedToken = fresh
+	t.mu.Unlock()
+
+	return fresh, nil
+}
+
+// retry resends a 429 or 503 response with capped exponential backoff and
+// jitter, honoring Retry-After when Reddit sends it
+func (t *Transport) retry(req *http.Request, res *http.Response) (*http.Response, error) {
+	retryAfter := res.Header.Get("Retry-After")
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		res.Body.Close()
+
+		select {
+		case <-time.After(backoff(retryAfter, attempt)):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		token, err := t.token(req.Context(), false)
+		if err != nil {
+			return nil, fmt.Errorf("reddit: refreshing oauth2 token: %w", err)
+		}
+
+		res, err = t.do(req, token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		t.recordRateLimit(res)
+
+		if res.StatusCode != http.StatusTooManyRequests && res.StatusCode != http.StatusServiceUnavailable {
+			break
+		}
+
+		retryAfter = res.Header.Get("Retry-After")
+	}
+
+	return res, nil
+}
+
+func backoff(retryAfter string, attempt int) time.Duration {
+	base := time.Second
+	if secs, err := strconv.Atoi(retryAfter); err == nil {
+		base = time.Duration(secs) * time.Second
+	}
+
+	delay := time.Duration(float64(base) * math.Pow(2, float64(attempt)))
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// recordRateLimit updates Transport's view of the rate limit window from
+// Reddit's X-Ratelimit-* response headers
+func (t *Transport) recordRateLimit(res *http.Response) {
+	remaining, err := strconv.ParseFloat(res.Header.Get("X-Ratelimit-Remaining"), 64)
+	if err != nil {
+		return
+	}
+	used, _ := strconv.Atoi(res.Header.Get("X-Ratelimit-Used"))
+	resetSeconds, _ := strconv.Atoi(res.Header.Get("X-Ratelimit-Reset"))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rate = RateLimit{
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Now().Add(time.Duration(resetSeconds) * time.Second),
+	}
+}
+
+// await blocks until there's budget left in the current rate limit window,
+// or returns ErrRateLimited if Transport isn't configured to block
+func (t *Transport) await(ctx context.Context) error {
+	t.mu.Lock()
+	rate := t.rate
+	t.mu.Unlock()
+
+	if rate.Remaining > 1 || rate.Reset.IsZero() {
+		return nil
+	}
+
+	wait := time.Until(rate.Reset)
+	if wait <= 0 {
+		return nil
+	}
+
+	if !t.block {
+		return ErrRateLimited
+	}
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Transport) rateLimit() RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rate
+}
+
+// RateLimit returns a snapshot of the rate limit state Reddit reported on
+// the most recently completed request, as tracked by the client's Transport
+func (c *Client) RateLimit() RateLimit {
+	if t, ok := c.client.Transport.(*Transport); ok {
+		return t.rateLimit()
+	}
+	return RateLimit{}
+}
@@ -0,0 +1,146 @@
+package reddit
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newResponse(status int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Header:     header,
+	}
+}
+
+func TestTransport_RetryRewindsBody(t *testing.T) {
+	var bodies []string
+
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			return newResponse(http.StatusUnauthorized, "", nil), nil
+		}
+		return newResponse(http.StatusOK, "ok", nil), nil
+	})
+
+	var fetches int32
+	transport := &Transport{
+		base: base,
+		fetch: func(ctx context.Context) (*oauth2.Token, error) {
+			atomic.AddInt32(&fetches, 1)
+			return &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}, nil
+		},
+		cachedToken: &oauth2.Token{AccessToken: "stale", Expiry: time.Now().Add(time.Hour)},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com", strings.NewReader("hello world"))
+	require.NoError(t, err)
+
+	res, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	require.Equal(t, []string{"hello world", "hello world"}, bodies)
+	require.EqualValues(t, 1, fetches)
+}
+
+func TestTransport_ForceRefreshAlwaysFetches(t *testing.T) {
+	var fetches int32
+	transport := &Transport{
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return newResponse(http.StatusOK, "ok", nil), nil
+		}),
+		fetch: func(ctx context.Context) (*oauth2.Token, error) {
+			atomic.AddInt32(&fetches, 1)
+			return &oauth2.Token{AccessToken: "fresh", Expiry: time.Now().Add(time.Hour)}, nil
+		},
+		cachedToken: &oauth2.Token{AccessToken: "cached", Expiry: time.Now().Add(time.Hour)},
+	}
+
+	_, err := transport.token(context.Background(), false)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, fetches, "a still-valid cached token should not be refetched")
+
+	_, err = transport.token(context.Background(), true)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, fetches, "forceRefresh must always hit the token endpoint, not the cache")
+}
+
+func TestTransport_Await_RateLimited(t *testing.T) {
+	transport := &Transport{
+		block: false,
+		rate: RateLimit{
+			Remaining: 0,
+			Reset:     time.Now().Add(50 * time.Millisecond),
+		},
+	}
+
+	err := transport.await(context.Background())
+	require.Equal(t, ErrRateLimited, err)
+}
+
+func TestTransport_Await_BlocksUntilReset(t *testing.T) {
+	transport := &Transport{
+		block: true,
+		rate: RateLimit{
+			Remaining: 0,
+			Reset:     time.Now().Add(50 * time.Millisecond),
+		},
+	}
+
+	start := time.Now()
+	err := transport.await(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestTransport_RetryOn429(t *testing.T) {
+	var attempts int32
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			header := make(http.Header)
+			header.Set("Retry-After", "0")
+			return newResponse(http.StatusTooManyRequests, "", header), nil
+		}
+		return newResponse(http.StatusOK, "ok", nil), nil
+	})
+
+	transport := &Transport{
+		base: base,
+		fetch: func(ctx context.Context) (*oauth2.Token, error) {
+			return &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}, nil
+		},
+		cachedToken: &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+
+	res, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.EqualValues(t, 3, attempts)
+}
@@ -0,0 +1,379 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+// WikiService handles communication with the wiki
+// related methods of the Reddit API
+type WikiService interface {
+	Page(ctx context.Context, subreddit, page string) (*WikiPage, *Response, error)
+	Pages(ctx context.Context, subreddit string) ([]string, *Response, error)
+	Settings(ctx context.Context, subreddit, page string) (*WikiPageSettings, *Response, error)
+	UpdateSettings(ctx context.Context, subreddit, page string, updateRequest *WikiPageSettingsUpdateRequest) (*WikiPageSettings, *Response, error)
+	Allow(ctx context.Context, subreddit, page, username string) (*Response, error)
+	Deny(ctx context.Context, subreddit, page, username string) (*Response, error)
+	Discussions(ctx context.Context, subreddit, page string, opts *ListOptions) ([]*Post, *Response, error)
+
+	Edit(ctx context.Context, subreddit, page string, editRequest *WikiPageEditRequest) (*Response, error)
+	Revisions(ctx context.Context, subreddit, page string, opts *ListOptions) ([]*WikiRevision, *Response, error)
+	RevisionsAll(ctx context.Context, subreddit string, opts *ListOptions) ([]*WikiRevision, *Response, error)
+	RevertTo(ctx context.Context, subreddit, page, revisionID string) (*Response, error)
+	ToggleHide(ctx context.Context, subreddit, page, revisionID string) (*Response, error)
+}
+
+// WikiServiceOp implements the WikiService interface
+type WikiServiceOp struct {
+	client *Client
+}
+
+var _ WikiService = &WikiServiceOp{}
+
+// WikiPermissionLevel is the visibility/edit permission level of a wiki page
+type WikiPermissionLevel int
+
+const (
+	// PermissionSubredditWikiPermissions uses the subreddit's overall wiki permissions
+	PermissionSubredditWikiPermissions WikiPermissionLevel = iota
+	// PermissionApprovedContributorsOnly restricts editing to approved wiki contributors
+	PermissionApprovedContributorsOnly
+	// PermissionOnlyModerators restricts editing to subreddit moderators
+	PermissionOnlyModerators
+)
+
+type rootWikiPage struct {
+	Data *WikiPage `json:"data,omitempty"`
+}
+
+// WikiPage is a single revision of a wiki page
+type WikiPage struct {
+	Content string `json:"content_md,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+
+	MayRevise bool `json:"may_revise"`
+
+	RevisionID   string     `json:"revision_id,omitempty"`
+	RevisionDate *Timestamp `json:"revision_date,omitempty"`
+	RevisionBy   *User      `json:"revision_by,omitempty"`
+}
+
+type rootWikiPages struct {
+	Data []string `json:"data"`
+}
+
+type rootWikiPageSettings struct {
+	Data *WikiPageSettings `json:"data,omitempty"`
+}
+
+// WikiPageSettings are the visibility and edit permissions of a wiki page
+type WikiPageSettings struct {
+	PermissionLevel WikiPermissionLevel `json:"permlevel"`
+	Listed          bool                `json:"listed"`
+	Editors         []*User             `json:"editors,omitempty"`
+}
+
+// WikiPageSettingsUpdateRequest represents a request to update a wiki page's settings
+type WikiPageSettingsUpdateRequest struct {
+	PermissionLevel WikiPermissionLevel `url:"permlevel"`
+	Listed          *bool               `url:"listed"`
+}
+
+type rootWikiPageDiscussions struct {
+	Data struct {
+		Children []*Post `json:"children"`
+	} `json:"data"`
+}
+
+// WikiPageEditRequest represents a request to create or edit a wiki page
+type WikiPageEditRequest struct {
+	Content string `url:"content"`
+	Reason  string `url:"reason,omitempty"`
+
+	// PreviousRevisionID, when set, is compared against the page's current
+	// revision on Reddit's end; a mismatch results in a WikiRevisionConflictError
+	PreviousRevisionID string `url:"previous,omitempty"`
+}
+
+type wikiEditResponse struct {
+	JSON wikiEditJSON `json:"json"`
+}
+
+type wikiEditJSON struct {
+	Errors [][]string `json:"errors"`
+}
+
+func (j *wikiEditJSON) err() error {
+	for _, e := range j.Errors {
+		if len(e) == 0 {
+			continue
+		}
+		if e[0] == "WIKI_REVISION_CONFLICT" {
+			conflict := &WikiRevisionConflictError{}
+			if len(e) > 1 {
+				conflict.Message = e[1]
+			}
+			return conflict
+		}
+	}
+	return nil
+}
+
+// WikiRevisionConflictError is returned by Edit when the previous revision
+// ID it was given no longer matches the page's current revision on Reddit's end
+type WikiRevisionConflictError struct {
+	Message string
+}
+
+func (e *WikiRevisionConflictError) Error() string {
+	return fmt.Sprintf("reddit: wiki revision conflict: %s", e.Message)
+}
+
+type rootWikiRevisions struct {
+	Data struct {
+		Children []*WikiRevision `json:"children"`
+	} `json:"data"`
+}
+
+// WikiRevision is a single entry in a wiki page's (or subreddit's) revision history
+type WikiRevision struct {
+	Page string `json:"page,omitempty"`
+
+	RevisionID string     `json:"revision,omitempty"`
+	Timestamp  *Timestamp `json:"timestamp,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+	Hidden     bool       `json:"revision_hidden"`
+
+	Author *User `json:"author,omitempty"`
+}
+
+// Page gets a wiki page
+func (s *WikiServiceOp) Page(ctx context.Context, subreddit, page string) (*WikiPage, *Response, error) {
+	path := fmt.Sprintf("r/%s/wiki/%s", subreddit, page)
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWikiPage)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Data, resp, nil
+}
+
+// Pages gets the names of all wiki pages in a subreddit
+func (s *WikiServiceOp) Pages(ctx context.Context, subreddit string) ([]string, *Response, error) {
+	path := fmt.Sprintf("r/%s/wiki/pages", subreddit)
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWikiPages)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Data, resp, nil
+}
+
+// Settings gets a wiki page's settings
+func (s *WikiServiceOp) Settings(ctx context.Context, subreddit, page string) (*WikiPageSettings, *Response, error) {
+	path := fmt.Sprintf("r/%s/wiki/settings/%s", subreddit, page)
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWikiPageSettings)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Data, resp, nil
+}
+
+// UpdateSettings updates a wiki page's settings
+func (s *WikiServiceOp) UpdateSettings(ctx context.Context, subreddit, page string, updateRequest *WikiPageSettingsUpdateRequest) (*WikiPageSettings, *Response, error) {
+	if updateRequest == nil {
+		return nil, nil, errors.New("updateRequest: cannot be nil")
+	}
+
+	path := fmt.Sprintf("r/%s/wiki/settings/%s", subreddit, page)
+
+	form, err := query.Values(updateRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewPostForm(path, form)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWikiPageSettings)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Data, resp, nil
+}
+
+// Allow adds an editor to a wiki page
+func (s *WikiServiceOp) Allow(ctx context.Context, subreddit, page, username string) (*Response, error) {
+	return s.editor(ctx, subreddit, page, username, "add")
+}
+
+// Deny removes an editor from a wiki page
+func (s *WikiServiceOp) Deny(ctx context.Context, subreddit, page, username string) (*Response, error) {
+	return s.editor(ctx, subreddit, page, username, "del")
+}
+
+func (s *WikiServiceOp) editor(ctx context.Context, subreddit, page, username, action string) (*Response, error) {
+	path := fmt.Sprintf("r/%s/api/wiki/alloweditor/%s", subreddit, action)
+
+	form := url.Values{}
+	form.Set("page", page)
+	form.Set("username", username)
+
+	req, err := s.client.NewPostForm(path, form)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// Discussions gets the posts that have linked to a wiki page
+func (s *WikiServiceOp) Discussions(ctx context.Context, subreddit, page string, opts *ListOptions) ([]*Post, *Response, error) {
+	path := fmt.Sprintf("r/%s/wiki/discussions/%s", subreddit, page)
+	path, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWikiPageDiscussions)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Data.Children, resp, nil
+}
+
+// Edit creates or updates a wiki page. If editRequest.PreviousRevisionID does
+// not match the page's current revision, a *WikiRevisionConflictError is returned
+func (s *WikiServiceOp) Edit(ctx context.Context, subreddit, page string, editRequest *WikiPageEditRequest) (*Response, error) {
+	if editRequest == nil {
+		return nil, errors.New("editRequest: cannot be nil")
+	}
+
+	path := fmt.Sprintf("r/%s/api/wiki/edit", subreddit)
+
+	form, err := query.Values(editRequest)
+	if err != nil {
+		return nil, err
+	}
+	form.Set("page", page)
+	form.Set("api_type", "json")
+
+	req, err := s.client.NewPostForm(path, form)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(wikiEditResponse)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := root.JSON.err(); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Revisions gets the revision history of a single wiki page
+func (s *WikiServiceOp) Revisions(ctx context.Context, subreddit, page string, opts *ListOptions) ([]*WikiRevision, *Response, error) {
+	path := fmt.Sprintf("r/%s/wiki/revisions/%s", subreddit, page)
+	return s.revisions(ctx, path, opts)
+}
+
+// RevisionsAll gets the revision history across every wiki page in a subreddit
+func (s *WikiServiceOp) RevisionsAll(ctx context.Context, subreddit string, opts *ListOptions) ([]*WikiRevision, *Response, error) {
+	path := fmt.Sprintf("r/%s/wiki/revisions", subreddit)
+	return s.revisions(ctx, path, opts)
+}
+
+func (s *WikiServiceOp) revisions(ctx context.Context, path string, opts *ListOptions) ([]*WikiRevision, *Response, error) {
+	path, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWikiRevisions)
+	resp, err := s.client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Data.Children, resp, nil
+}
+
+// RevertTo reverts a wiki page to a previous revision
+func (s *WikiServiceOp) RevertTo(ctx context.Context, subreddit, page, revisionID string) (*Response, error) {
+	path := fmt.Sprintf("r/%s/api/wiki/revert", subreddit)
+
+	form := url.Values{}
+	form.Set("page", page)
+	form.Set("revision", revisionID)
+
+	req, err := s.client.NewPostForm(path, form)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// ToggleHide hides or unhides a revision from a wiki page's public revision history
+func (s *WikiServiceOp) ToggleHide(ctx context.Context, subreddit, page, revisionID string) (*Response, error) {
+	path := fmt.Sprintf("r/%s/api/wiki/hide", subreddit)
+
+	form := url.Values{}
+	form.Set("page", page)
+	form.Set("revision", revisionID)
+
+	req, err := s.client.NewPostForm(path, form)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
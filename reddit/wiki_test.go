@@ -48,6 +48,29 @@ var expectedWikiPageSettings = &WikiPageSettings{
 	},
 }
 
+var expectedWikiRevisions = []*WikiRevision{
+	{
+		Page: "testpage",
+
+		RevisionID: "3c4e9fab-ef2c-11ea-90b6-0e9189256887",
+		Timestamp:  &Timestamp{time.Date(2020, 9, 5, 3, 59, 45, 0, time.UTC)},
+		Reason:     "this is a reason!",
+		Hidden:     false,
+
+		Author: &User{
+			ID:      "164ab8",
+			Name:    "v_95",
+			Created: &Timestamp{time.Date(2017, 3, 12, 4, 56, 47, 0, time.UTC)},
+
+			PostKarma:    691,
+			CommentKarma: 22235,
+
+			HasVerifiedEmail: true,
+			NSFW:             true,
+		},
+	},
+}
+
 var expectedWikiPageDiscussions = []*Post{
 	{
 		ID:      "imj8g5",
@@ -216,4 +239,125 @@ func TestWikiService_Discussions(t *testing.T) {
 	wikiPageDiscussions, _, err := client.Wiki.Discussions(ctx, "testsubreddit", "testpage", nil)
 	require.NoError(t, err)
 	require.Equal(t, expectedWikiPageDiscussions, wikiPageDiscussions)
+}
+
+func TestWikiService_Edit(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/r/testsubreddit/api/wiki/edit", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("content", "hello world")
+		form.Set("reason", "testing")
+		form.Set("previous", "abc123")
+		form.Set("page", "testpage")
+		form.Set("api_type", "json")
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		require.Equal(t, form, r.PostForm)
+
+		fmt.Fprint(w, `{"json":{"errors":[]}}`)
+	})
+
+	_, err := client.Wiki.Edit(ctx, "testsubreddit", "testpage", nil)
+	require.EqualError(t, err, "editRequest: cannot be nil")
+
+	_, err = client.Wiki.Edit(ctx, "testsubreddit", "testpage", &WikiPageEditRequest{
+		Content:            "hello world",
+		Reason:             "testing",
+		PreviousRevisionID: "abc123",
+	})
+	require.NoError(t, err)
+}
+
+func TestWikiService_Edit_RevisionConflict(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/r/testsubreddit/api/wiki/edit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"json":{"errors":[["WIKI_REVISION_CONFLICT", "the page has changed since you started editing", "previous"]]}}`)
+	})
+
+	_, err := client.Wiki.Edit(ctx, "testsubreddit", "testpage", &WikiPageEditRequest{Content: "hello world"})
+
+	var conflict *WikiRevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, "the page has changed since you started editing", conflict.Message)
+}
+
+func TestWikiService_Revisions(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	blob, err := readFileContents("../testdata/wiki/revisions.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/r/testsubreddit/wiki/revisions/testpage", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		fmt.Fprint(w, blob)
+	})
+
+	wikiRevisions, _, err := client.Wiki.Revisions(ctx, "testsubreddit", "testpage", nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedWikiRevisions, wikiRevisions)
+}
+
+func TestWikiService_RevisionsAll(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	blob, err := readFileContents("../testdata/wiki/revisions.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/r/testsubreddit/wiki/revisions", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		fmt.Fprint(w, blob)
+	})
+
+	wikiRevisions, _, err := client.Wiki.RevisionsAll(ctx, "testsubreddit", nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedWikiRevisions, wikiRevisions)
+}
+
+func TestWikiService_RevertTo(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/r/testsubreddit/api/wiki/revert", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("page", "testpage")
+		form.Set("revision", "3c4e9fab-ef2c-11ea-90b6-0e9189256887")
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		require.Equal(t, form, r.PostForm)
+	})
+
+	_, err := client.Wiki.RevertTo(ctx, "testsubreddit", "testpage", "3c4e9fab-ef2c-11ea-90b6-0e9189256887")
+	require.NoError(t, err)
+}
+
+func TestWikiService_ToggleHide(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/r/testsubreddit/api/wiki/hide", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("page", "testpage")
+		form.Set("revision", "3c4e9fab-ef2c-11ea-90b6-0e9189256887")
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		require.Equal(t, form, r.PostForm)
+	})
+
+	_, err := client.Wiki.ToggleHide(ctx, "testsubreddit", "testpage", "3c4e9fab-ef2c-11ea-90b6-0e9189256887")
+	require.NoError(t, err)
 }
\ No newline at end of file